@@ -0,0 +1,211 @@
+package marker
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/xerrors"
+)
+
+// region CodecID //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// CodecID is the first field of every ObjectStorageValue representation in this package and of SequenceIDs.Bytes; in
+// the Bytes of a Sequence or SequenceAliasMapping it directly follows the ObjectStorageKey. It identifies the
+// on-disk layout that the remaining bytes follow so that Sequence, SequenceAliasMapping and SequenceIDs can change
+// their encoding over time without losing the ability to load entries that were written by an older version of the
+// node. SequenceAlias is deliberately left out: it is used as an ObjectStorage key (see
+// SequenceAliasMapping.ObjectStorageKey) and as the human/API-visible Base58 identity of an alias, so its on-disk
+// length and content must stay fixed.
+type CodecID uint32
+
+const (
+	// NoCodec marks entries that were written before CodecIDs existed. There is no default decoder registered for
+	// it - code that needs to load such legacy entries (e.g. a migration or an integration test that wants to force
+	// a historical encoding) has to opt in explicitly by registering a decoder for it.
+	NoCodec CodecID = iota
+
+	// GenericV1 is the layout that this package produced before Sequence grew a FinalizedIndex.
+	GenericV1
+
+	// GenericV2 is the layout that is currently produced by this package. It extends GenericV1 by appending the
+	// Sequence's FinalizedIndex, see Sequence.SetFinalizedIndex.
+	GenericV2
+)
+
+// CodecIDFromBytes unmarshals a CodecID from a sequence of bytes.
+func CodecIDFromBytes(codecIDBytes []byte) (codecID CodecID, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(codecIDBytes)
+	if codecID, err = CodecIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse CodecID from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// CodecIDFromMarshalUtil is a wrapper for simplified unmarshaling in a byte stream using the marshalUtil package.
+func CodecIDFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (codecID CodecID, err error) {
+	untypedCodecID, err := marshalUtil.ReadUint32()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse CodecID (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	codecID = CodecID(untypedCodecID)
+
+	return
+}
+
+// Bytes returns the CodecID in serialized byte form.
+func (c CodecID) Bytes() []byte {
+	return marshalutil.New().WriteUint32(uint32(c)).Bytes()
+}
+
+// Verify returns an error if the CodecID is not a known, registered identifier.
+func (c CodecID) Verify() error {
+	codecIDNamesMutex.RLock()
+	defer codecIDNamesMutex.RUnlock()
+
+	if _, known := codecIDNames[c]; !known {
+		return xerrors.Errorf("CodecID(%d): %w", c, ErrUnknownCodecID)
+	}
+
+	return nil
+}
+
+// String returns a human readable version of the CodecID.
+func (c CodecID) String() string {
+	codecIDNamesMutex.RLock()
+	defer codecIDNamesMutex.RUnlock()
+
+	if name, exists := codecIDNames[c]; exists {
+		return name
+	}
+
+	return "CodecID(" + strconv.FormatUint(uint64(c), 10) + ")"
+}
+
+// RegisterCodecID makes a CodecID pass Verify and gives it a human readable name. Downstream code that introduces a
+// custom codec (or wants to make a historical layout loadable again) needs to call this in addition to registering
+// the actual decoder for the types it wants to support.
+func RegisterCodecID(codec CodecID, name string) {
+	codecIDNamesMutex.Lock()
+	defer codecIDNamesMutex.Unlock()
+
+	codecIDNames[codec] = name
+}
+
+var (
+	// codecIDNames holds the set of CodecIDs that are considered known by Verify, together with their display name.
+	codecIDNames = map[CodecID]string{
+		NoCodec:   "NoCodec",
+		GenericV1: "GenericV1",
+		GenericV2: "GenericV2",
+	}
+	codecIDNamesMutex sync.RWMutex
+)
+
+// ErrUnknownCodecID is returned when a CodecID is encountered that was never registered via RegisterCodecID.
+var ErrUnknownCodecID = xerrors.New("unknown CodecID")
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region decoder registries //////////////////////////////////////////////////////////////////////////////////////
+
+// sequenceDecoder decodes the part of a Sequence that follows its CodecID.
+type sequenceDecoder func(sequence *Sequence, marshalUtil *marshalutil.MarshalUtil) error
+
+// sequenceAliasMappingDecoder decodes the part of a SequenceAliasMapping that follows its CodecID.
+type sequenceAliasMappingDecoder func(mapping *SequenceAliasMapping, marshalUtil *marshalutil.MarshalUtil) error
+
+// sequenceIDsDecoder decodes the part of a SequenceIDs collection that follows its CodecID.
+type sequenceIDsDecoder func(marshalUtil *marshalutil.MarshalUtil) (sequenceIDs SequenceIDs, err error)
+
+var (
+	sequenceDecoders = map[CodecID]sequenceDecoder{
+		GenericV1: sequenceFromMarshalUtilGenericV1,
+		GenericV2: sequenceFromMarshalUtilGenericV2,
+	}
+	sequenceDecodersMutex sync.RWMutex
+
+	sequenceAliasMappingDecoders = map[CodecID]sequenceAliasMappingDecoder{
+		GenericV1: sequenceAliasMappingFromMarshalUtilGenericV1,
+	}
+	sequenceAliasMappingDecodersMutex sync.RWMutex
+
+	sequenceIDsDecoders = map[CodecID]sequenceIDsDecoder{
+		GenericV1: sequenceIDsFromMarshalUtilGenericV1,
+	}
+	sequenceIDsDecodersMutex sync.RWMutex
+)
+
+// RegisterSequenceCodec registers the decoder that is used to parse a Sequence that was encoded with the given
+// CodecID. It allows downstream code to teach this package how to load Sequences with a custom or historical
+// on-disk layout.
+func RegisterSequenceCodec(codec CodecID, decode func(sequence *Sequence, marshalUtil *marshalutil.MarshalUtil) error) {
+	sequenceDecodersMutex.Lock()
+	defer sequenceDecodersMutex.Unlock()
+
+	sequenceDecoders[codec] = decode
+}
+
+// decoderForSequence returns the decoder that is registered for the given CodecID.
+func decoderForSequence(codec CodecID) (decode sequenceDecoder, err error) {
+	sequenceDecodersMutex.RLock()
+	defer sequenceDecodersMutex.RUnlock()
+
+	decode, exists := sequenceDecoders[codec]
+	if !exists {
+		return nil, xerrors.Errorf("no Sequence decoder registered for %s: %w", codec, ErrUnknownCodecID)
+	}
+
+	return decode, nil
+}
+
+// RegisterSequenceAliasMappingCodec registers the decoder that is used to parse a SequenceAliasMapping that was
+// encoded with the given CodecID.
+func RegisterSequenceAliasMappingCodec(codec CodecID, decode func(mapping *SequenceAliasMapping, marshalUtil *marshalutil.MarshalUtil) error) {
+	sequenceAliasMappingDecodersMutex.Lock()
+	defer sequenceAliasMappingDecodersMutex.Unlock()
+
+	sequenceAliasMappingDecoders[codec] = decode
+}
+
+// decoderForSequenceAliasMapping returns the decoder that is registered for the given CodecID.
+func decoderForSequenceAliasMapping(codec CodecID) (decode sequenceAliasMappingDecoder, err error) {
+	sequenceAliasMappingDecodersMutex.RLock()
+	defer sequenceAliasMappingDecodersMutex.RUnlock()
+
+	decode, exists := sequenceAliasMappingDecoders[codec]
+	if !exists {
+		return nil, xerrors.Errorf("no SequenceAliasMapping decoder registered for %s: %w", codec, ErrUnknownCodecID)
+	}
+
+	return decode, nil
+}
+
+// RegisterSequenceIDsCodec registers the decoder that is used to parse a SequenceIDs collection that was encoded
+// with the given CodecID.
+func RegisterSequenceIDsCodec(codec CodecID, decode func(marshalUtil *marshalutil.MarshalUtil) (SequenceIDs, error)) {
+	sequenceIDsDecodersMutex.Lock()
+	defer sequenceIDsDecodersMutex.Unlock()
+
+	sequenceIDsDecoders[codec] = decode
+}
+
+// decoderForSequenceIDs returns the decoder that is registered for the given CodecID.
+func decoderForSequenceIDs(codec CodecID) (decode sequenceIDsDecoder, err error) {
+	sequenceIDsDecodersMutex.RLock()
+	defer sequenceIDsDecodersMutex.RUnlock()
+
+	decode, exists := sequenceIDsDecoders[codec]
+	if !exists {
+		return nil, xerrors.Errorf("no SequenceIDs decoder registered for %s: %w", codec, ErrUnknownCodecID)
+	}
+
+	return decode, nil
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////
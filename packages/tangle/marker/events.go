@@ -0,0 +1,26 @@
+package marker
+
+import (
+	"github.com/iotaledger/hive.go/events"
+)
+
+// region Events ///////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Events contains the events that are triggered by this package.
+var Events = &packageEvents{
+	MarkerFinalized: events.NewEvent(markerFinalizedEventCaller),
+}
+
+// packageEvents bundles the events that are triggered by the marker package.
+type packageEvents struct {
+	// MarkerFinalized is triggered when Sequence.SetFinalizedIndex advances a Sequence's FinalizedIndex to cover a
+	// new Marker, i.e. when that Marker (and everything below it) becomes finalized.
+	MarkerFinalized *events.Event
+}
+
+// markerFinalizedEventCaller is the events.Event caller for handlers that are notified about a finalized Marker.
+func markerFinalizedEventCaller(handler interface{}, params ...interface{}) {
+	handler.(func(sequenceID SequenceID, index Index))(params[0].(SequenceID), params[1].(Index))
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -3,6 +3,7 @@ package marker
 import (
 	"sort"
 	"strconv"
+	"sync"
 
 	"github.com/iotaledger/hive.go/byteutils"
 	"github.com/iotaledger/hive.go/cerrors"
@@ -22,6 +23,9 @@ type Sequence struct {
 	rank             uint64
 	highestIndex     Index
 
+	finalizedIndex      Index
+	finalizedIndexMutex sync.RWMutex
+
 	objectstorage.StorableObjectFlags
 }
 
@@ -54,22 +58,59 @@ func SequenceFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (sequence *Se
 		err = xerrors.Errorf("failed to parse SequenceID from MarshalUtil: %w", err)
 		return
 	}
-	if sequence.parentReferences, err = ParentReferencesFromMarshalUtil(marshalUtil); err != nil {
-		err = xerrors.Errorf("failed to parse ParentReferences from MarshalUtil: %w", err)
+
+	codec, err := CodecIDFromMarshalUtil(marshalUtil)
+	if err != nil {
+		err = xerrors.Errorf("failed to parse CodecID from MarshalUtil: %w", err)
 		return
 	}
-	if sequence.rank, err = marshalUtil.ReadUint64(); err != nil {
-		err = xerrors.Errorf("failed to parse rank (%v): %w", err, cerrors.ErrParseBytesFailed)
+	if err = codec.Verify(); err != nil {
+		err = xerrors.Errorf("failed to verify CodecID of Sequence: %w", err)
 		return
 	}
-	if sequence.highestIndex, err = IndexFromMarshalUtil(marshalUtil); err != nil {
-		err = xerrors.Errorf("failed to parse highest Index from MarshalUtil: %w", err)
+
+	decode, err := decoderForSequence(codec)
+	if err != nil {
+		err = xerrors.Errorf("failed to look up decoder for Sequence: %w", err)
+		return
+	}
+	if err = decode(sequence, marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to decode Sequence body encoded with %s: %w", codec, err)
 		return
 	}
 
 	return
 }
 
+// sequenceFromMarshalUtilGenericV1 decodes the part of a Sequence that follows its CodecID, using the GenericV1 layout
+// (without the FinalizedIndex) that this package produced before Sequences were written as GenericV2.
+func sequenceFromMarshalUtilGenericV1(sequence *Sequence, marshalUtil *marshalutil.MarshalUtil) (err error) {
+	if sequence.parentReferences, err = ParentReferencesFromMarshalUtil(marshalUtil); err != nil {
+		return xerrors.Errorf("failed to parse ParentReferences from MarshalUtil: %w", err)
+	}
+	if sequence.rank, err = marshalUtil.ReadUint64(); err != nil {
+		return xerrors.Errorf("failed to parse rank (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
+	if sequence.highestIndex, err = IndexFromMarshalUtil(marshalUtil); err != nil {
+		return xerrors.Errorf("failed to parse highest Index from MarshalUtil: %w", err)
+	}
+
+	return nil
+}
+
+// sequenceFromMarshalUtilGenericV2 decodes the part of a Sequence that follows its CodecID, extending
+// sequenceFromMarshalUtilGenericV1 with the Sequence's FinalizedIndex.
+func sequenceFromMarshalUtilGenericV2(sequence *Sequence, marshalUtil *marshalutil.MarshalUtil) (err error) {
+	if err = sequenceFromMarshalUtilGenericV1(sequence, marshalUtil); err != nil {
+		return xerrors.Errorf("failed to parse GenericV1 Sequence fields: %w", err)
+	}
+	if sequence.finalizedIndex, err = IndexFromMarshalUtil(marshalUtil); err != nil {
+		return xerrors.Errorf("failed to parse finalized Index from MarshalUtil: %w", err)
+	}
+
+	return nil
+}
+
 // SequenceFromObjectStorage restores an Sequence that was stored in the ObjectStorage.
 func SequenceFromObjectStorage(key []byte, data []byte) (sequence objectstorage.StorableObject, err error) {
 	if sequence, _, err = SequenceFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
@@ -105,6 +146,33 @@ func (s *Sequence) HighestIndex() Index {
 	return s.highestIndex
 }
 
+// FinalizedIndex returns the highest Index in this Sequence that has been finalized by the voting layer (see
+// marker/vote). Every Marker in this Sequence at or below it is implicitly finalized as well.
+func (s *Sequence) FinalizedIndex() Index {
+	s.finalizedIndexMutex.RLock()
+	defer s.finalizedIndexMutex.RUnlock()
+
+	return s.finalizedIndex
+}
+
+// SetFinalizedIndex updates the FinalizedIndex of the Sequence if index is higher than its current value. It
+// returns true if the FinalizedIndex was advanced and triggers Events.MarkerFinalized for the newly finalized
+// Marker in that case.
+func (s *Sequence) SetFinalizedIndex(index Index) (updated bool) {
+	s.finalizedIndexMutex.Lock()
+	if index <= s.finalizedIndex {
+		s.finalizedIndexMutex.Unlock()
+		return false
+	}
+	s.finalizedIndex = index
+	s.finalizedIndexMutex.Unlock()
+
+	s.SetModified()
+	Events.MarkerFinalized.Trigger(s.id, index)
+
+	return true
+}
+
 // Bytes returns the Sequence in serialized byte form.
 func (s *Sequence) Bytes() []byte {
 	return byteutils.ConcatBytes(s.ObjectStorageKey(), s.ObjectStorageValue())
@@ -122,12 +190,15 @@ func (s *Sequence) ObjectStorageKey() []byte {
 }
 
 // ObjectStorageValue marshals the Sequence into a sequence of bytes. The ID is not serialized here as it is only used as
-// a key in the ObjectStorage.
+// a key in the ObjectStorage. The CodecID is written first so that the remaining fields can be decoded according to
+// the layout that they were encoded with, see decoderForSequence.
 func (s *Sequence) ObjectStorageValue() []byte {
 	return marshalutil.New().
+		Write(GenericV2).
 		Write(s.parentReferences).
 		WriteUint64(s.rank).
 		Write(s.HighestIndex()).
+		Write(s.FinalizedIndex()).
 		Bytes()
 }
 
@@ -244,20 +315,44 @@ func SequenceIDsFromBytes(sequenceIDBytes []byte) (sequenceIDs SequenceIDs, cons
 
 // SequenceIDsFromMarshalUtil unmarshals a collection of Sequence IDs using a MarshalUtil (for easier unmarshaling).
 func SequenceIDsFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (sequenceIDs SequenceIDs, err error) {
-	sequenceIDsCount, err := marshalUtil.ReadUint32()
+	codec, err := CodecIDFromMarshalUtil(marshalUtil)
+	if err != nil {
+		err = xerrors.Errorf("failed to parse CodecID from MarshalUtil: %w", err)
+		return
+	}
+	if err = codec.Verify(); err != nil {
+		err = xerrors.Errorf("failed to verify CodecID of SequenceIDs: %w", err)
+		return
+	}
+
+	decode, err := decoderForSequenceIDs(codec)
 	if err != nil {
-		err = xerrors.Errorf("failed to parse SequenceIDs count (%v): %w", err, cerrors.ErrParseBytesFailed)
+		err = xerrors.Errorf("failed to look up decoder for SequenceIDs: %w", err)
 		return
 	}
+	if sequenceIDs, err = decode(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to decode SequenceIDs body encoded with %s: %w", codec, err)
+		return
+	}
+
+	return
+}
+
+// sequenceIDsFromMarshalUtilGenericV1 decodes the part of a SequenceIDs collection that follows its CodecID, using
+// the layout that this package currently produces.
+func sequenceIDsFromMarshalUtilGenericV1(marshalUtil *marshalutil.MarshalUtil) (sequenceIDs SequenceIDs, err error) {
+	sequenceIDsCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to parse SequenceIDs count (%v): %w", err, cerrors.ErrParseBytesFailed)
+	}
 	sequenceIDs = make(SequenceIDs, sequenceIDsCount)
 	for i := uint32(0); i < sequenceIDsCount; i++ {
 		if sequenceIDs[i], err = SequenceIDFromMarshalUtil(marshalUtil); err != nil {
-			err = xerrors.Errorf("failed to parse SequenceID from MarshalUtil: %w", err)
-			return
+			return nil, xerrors.Errorf("failed to parse SequenceID from MarshalUtil: %w", err)
 		}
 	}
 
-	return
+	return sequenceIDs, nil
 }
 
 // SequenceAlias returns a SequenceAlias computed from SequenceIDs.
@@ -271,9 +366,11 @@ func (s SequenceIDs) SequenceAlias() (aggregatedSequencesID SequenceAlias) {
 	return
 }
 
-// Bytes returns the SequenceIDs in serialized byte form.
+// Bytes returns the SequenceIDs in serialized byte form, with a CodecID identifying the layout of the entries that
+// follow (see decoderForSequenceIDs).
 func (s SequenceIDs) Bytes() []byte {
 	marshalUtil := marshalutil.New()
+	marshalUtil.Write(GenericV1)
 	marshalUtil.WriteUint32(uint32(len(s)))
 	for _, sequenceID := range s {
 		marshalUtil.Write(sequenceID)
@@ -320,16 +417,18 @@ func SequenceAliasFromBase58(base58String string) (aggregatedSequencesID Sequenc
 	return
 }
 
-// SequenceAliasFromMarshalUtil unmarshals a SequenceAlias using a MarshalUtil (for easier unmarshaling).
+// SequenceAliasFromMarshalUtil unmarshals a SequenceAlias using a MarshalUtil (for easier unmarshaling). Unlike the
+// other types in this package, SequenceAlias does not carry a CodecID: it is used as an ObjectStorage key (see
+// SequenceAliasMapping.ObjectStorageKey) and as the human/API-visible Base58 identity of an alias, and both of those
+// must keep a fixed, content-addressed length.
 func SequenceAliasFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (aggregatedSequencesID SequenceAlias, err error) {
 	aggregatedSequencesIDBytes, err := marshalUtil.ReadBytes(SequenceAliasLength)
 	if err != nil {
-		err = xerrors.Errorf("failed to parse SequenceAlias (%v): %w", err, cerrors.ErrParseBytesFailed)
-		return
+		return aggregatedSequencesID, xerrors.Errorf("failed to parse SequenceAlias (%v): %w", err, cerrors.ErrParseBytesFailed)
 	}
 	copy(aggregatedSequencesID[:], aggregatedSequencesIDBytes)
 
-	return
+	return aggregatedSequencesID, nil
 }
 
 // Bytes returns the bytes of the SequenceAlias.
@@ -378,14 +477,40 @@ func SequenceAliasMappingFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (
 		err = xerrors.Errorf("failed to parse SequenceAlias from MarshalUtil: %w", err)
 		return
 	}
-	if mapping.sequenceID, err = SequenceIDFromMarshalUtil(marshalUtil); err != nil {
-		err = xerrors.Errorf("failed to parse SequenceID from MarshalUtil: %w", err)
+
+	codec, err := CodecIDFromMarshalUtil(marshalUtil)
+	if err != nil {
+		err = xerrors.Errorf("failed to parse CodecID from MarshalUtil: %w", err)
+		return
+	}
+	if err = codec.Verify(); err != nil {
+		err = xerrors.Errorf("failed to verify CodecID of SequenceAliasMapping: %w", err)
+		return
+	}
+
+	decode, err := decoderForSequenceAliasMapping(codec)
+	if err != nil {
+		err = xerrors.Errorf("failed to look up decoder for SequenceAliasMapping: %w", err)
+		return
+	}
+	if err = decode(mapping, marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to decode SequenceAliasMapping body encoded with %s: %w", codec, err)
 		return
 	}
 
 	return
 }
 
+// sequenceAliasMappingFromMarshalUtilGenericV1 decodes the part of a SequenceAliasMapping that follows its CodecID,
+// using the layout that this package currently produces.
+func sequenceAliasMappingFromMarshalUtilGenericV1(mapping *SequenceAliasMapping, marshalUtil *marshalutil.MarshalUtil) (err error) {
+	if mapping.sequenceID, err = SequenceIDFromMarshalUtil(marshalUtil); err != nil {
+		return xerrors.Errorf("failed to parse SequenceID from MarshalUtil: %w", err)
+	}
+
+	return nil
+}
+
 // SequenceAliasMappingFromObjectStorage restores a SequenceAlias that was stored in the ObjectStorage.
 func SequenceAliasMappingFromObjectStorage(key []byte, data []byte) (mapping objectstorage.StorableObject, err error) {
 	if mapping, _, err = SequenceAliasMappingFromBytes(data); err != nil {
@@ -423,9 +548,13 @@ func (a *SequenceAliasMapping) ObjectStorageKey() []byte {
 }
 
 // ObjectStorageValue marshals the Transaction into a sequence of bytes. The ID is not serialized here as it is only
-// used as a key in the ObjectStorage.
+// used as a key in the ObjectStorage. The CodecID is written first so that the remaining fields can be decoded
+// according to the layout that they were encoded with, see decoderForSequenceAliasMapping.
 func (a *SequenceAliasMapping) ObjectStorageValue() []byte {
-	return a.sequenceID.Bytes()
+	return marshalutil.New().
+		Write(GenericV1).
+		Write(a.sequenceID).
+		Bytes()
 }
 
 var _ objectstorage.StorableObject = &SequenceAliasMapping{}
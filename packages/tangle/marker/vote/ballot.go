@@ -0,0 +1,61 @@
+package vote
+
+// region roundBallot //////////////////////////////////////////////////////////////////////////////////////////////
+
+// roundBallot collects the votes of a single voting round for a single Marker and filters out double votes: once a
+// Voter has cast an accepted vote in this round, any further vote it casts is discarded. A Round only ever polls for
+// one Marker at a time, so this only ever catches the same Voter being sampled twice by PeerPicker in one round -
+// it does not (and, with one Marker per round, cannot) detect a Voter casting conflicting votes across Sequences;
+// that would require tallying an entire batch of a Voter's votes at once, which this Manager does not do.
+type roundBallot struct {
+	voted    map[string]bool
+	quorum   int
+	likes    int
+	dislikes int
+}
+
+// newRoundBallot creates a roundBallot that requires at least quorum accepted votes before Majority will report a
+// decided Opinion.
+func newRoundBallot(quorum int) *roundBallot {
+	return &roundBallot{
+		voted:  make(map[string]bool),
+		quorum: quorum,
+	}
+}
+
+// Record adds a single Voter's Opinion to the ballot. It returns false if the vote was discarded as a double vote.
+func (r *roundBallot) Record(voter Voter, opinion Opinion) (accepted bool) {
+	if r.voted[voter.ID()] {
+		return false
+	}
+	r.voted[voter.ID()] = true
+
+	if opinion {
+		r.likes++
+	} else {
+		r.dislikes++
+	}
+
+	return true
+}
+
+// Majority returns the Opinion that was backed by more than half of the accepted votes. It reports no majority if
+// fewer than quorum votes were accepted, so that a handful of responses out of a much larger, mostly-unresponsive
+// committee cannot decide the round on their own.
+func (r *roundBallot) Majority() (majority Opinion, hasMajority bool) {
+	total := r.likes + r.dislikes
+	if total < r.quorum {
+		return Dislike, false
+	}
+
+	if r.likes > total/2 {
+		return Like, true
+	}
+	if r.dislikes > total/2 {
+		return Dislike, true
+	}
+
+	return Dislike, false
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////
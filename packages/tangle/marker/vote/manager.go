@@ -0,0 +1,137 @@
+package vote
+
+import (
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"golang.org/x/xerrors"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/marker"
+)
+
+// region Manager //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Manager drives Snowball-style voting on top of marker Sequences. Every Round it samples a committee of Voters,
+// tallies their opinions on a single Marker while filtering duplicate votes and requiring a minimum number of
+// responses to trust the result, and advances (or resets) that Marker's confidence accordingly. Once a Marker's
+// confidence reaches Beta with a Like preference it is finalized, which cascades to every earlier Marker of the same
+// Sequence (through Sequence.SetFinalizedIndex) and to every Marker reachable through HighestReferencedParentMarkers.
+type Manager struct {
+	markerVoteStorage *objectstorage.ObjectStorage
+
+	sequence      SequenceRetriever
+	parentMarkers ParentMarkersRetriever
+	peerPicker    PeerPicker
+	query         Query
+
+	k      int
+	quorum int
+	beta   uint64
+}
+
+// NewManager creates a Manager that votes with committees of size k, requires at least quorum accepted (non-erroring)
+// votes before a round is allowed to decide anything, and finalizes a Marker once its confidence reaches beta.
+// markerVoteStorage is expected to be wired up by the caller exactly like every other ObjectStorage in this package
+// family (same options, same persistence backend as e.g. the Sequence storage). sequence and parentMarkers give the
+// Manager read access to the marker DAG without this package depending on whatever component owns it; peerPicker and
+// query are the injection point that lets any gossip/peer layer drive the voting rounds.
+func NewManager(markerVoteStorage *objectstorage.ObjectStorage, sequence SequenceRetriever, parentMarkers ParentMarkersRetriever, peerPicker PeerPicker, query Query, k int, quorum int, beta uint64) *Manager {
+	return &Manager{
+		markerVoteStorage: markerVoteStorage,
+		sequence:          sequence,
+		parentMarkers:     parentMarkers,
+		peerPicker:        peerPicker,
+		query:             query,
+		k:                 k,
+		quorum:            quorum,
+		beta:              beta,
+	}
+}
+
+// MarkerVote retrieves the MarkerVote for the Marker identified by sequenceID and index, creating it with
+// initialPreference as its starting preference if it does not exist yet.
+func (m *Manager) MarkerVote(sequenceID marker.SequenceID, index marker.Index, initialPreference Opinion) *CachedMarkerVote {
+	key := byteutils.ConcatBytes(sequenceID.Bytes(), index.Bytes())
+
+	return &CachedMarkerVote{CachedObject: m.markerVoteStorage.ComputeIfAbsent(key, func(key []byte) objectstorage.StorableObject {
+		newMarkerVote := NewMarkerVote(sequenceID, index, initialPreference)
+		newMarkerVote.Persist()
+		newMarkerVote.SetModified()
+
+		return newMarkerVote
+	})}
+}
+
+// Round runs a single voting round for the Marker identified by sequenceID and index: it polls k Voters for their
+// opinion, tallies the result while filtering duplicate votes and requiring at least quorum accepted votes, and
+// applies the outcome to that Marker's MarkerVote. It returns true if the round finalized the Marker, either just
+// now or because it had already been finalized before.
+func (m *Manager) Round(sequenceID marker.SequenceID, index marker.Index) (finalized bool, err error) {
+	cachedSequence := m.sequence(sequenceID)
+	if cachedSequence == nil {
+		return false, xerrors.Errorf("failed to load Sequence %s", sequenceID)
+	}
+	defer cachedSequence.Release()
+
+	sequence := cachedSequence.Unwrap()
+	if sequence == nil {
+		return false, xerrors.Errorf("failed to unwrap Sequence %s", sequenceID)
+	}
+	if index <= sequence.FinalizedIndex() {
+		return true, nil
+	}
+
+	if !m.MarkerVote(sequenceID, index, Dislike).Consume(func(markerVote *MarkerVote) {
+		ballot := newRoundBallot(m.quorum)
+		for _, voter := range m.peerPicker(m.k) {
+			opinion, queryErr := m.query(voter, sequenceID, index)
+			if queryErr != nil {
+				continue
+			}
+
+			ballot.Record(voter, opinion)
+		}
+
+		majority, hasMajority := ballot.Majority()
+		if !hasMajority {
+			return
+		}
+
+		if markerVote.Tally(majority, m.beta) {
+			finalized = m.finalize(sequenceID, index)
+		}
+	}) {
+		return false, xerrors.Errorf("failed to load MarkerVote for Marker (%s, %s)", sequenceID, index)
+	}
+
+	return finalized, nil
+}
+
+// finalize marks the Marker identified by sequenceID and index as finalized and cascades that finalization to every
+// earlier Marker of the same Sequence and to every Marker reachable through HighestReferencedParentMarkers. It also
+// forces the MarkerVote of every Marker it touches to Like/finalized, so that a parent Marker that is finalized
+// purely through cascade (and never reached beta through its own voting rounds) still reports a MarkerVote state
+// consistent with its Sequence's FinalizedIndex.
+func (m *Manager) finalize(sequenceID marker.SequenceID, index marker.Index) (finalized bool) {
+	cachedSequence := m.sequence(sequenceID)
+	if cachedSequence == nil {
+		return false
+	}
+	defer cachedSequence.Release()
+
+	sequence := cachedSequence.Unwrap()
+	if sequence == nil || !sequence.SetFinalizedIndex(index) {
+		return false
+	}
+
+	m.MarkerVote(sequenceID, index, Like).Consume(func(markerVote *MarkerVote) {
+		markerVote.ForceFinalize(m.beta)
+	})
+
+	for parentSequenceID, parentIndex := range m.parentMarkers(sequenceID, index) {
+		m.finalize(parentSequenceID, parentIndex)
+	}
+
+	return true
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,240 @@
+package vote
+
+import (
+	"sync"
+
+	"github.com/iotaledger/hive.go/byteutils"
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"github.com/iotaledger/hive.go/objectstorage"
+	"golang.org/x/xerrors"
+
+	"github.com/iotaledger/goshimmer/packages/tangle/marker"
+)
+
+// region MarkerVote ///////////////////////////////////////////////////////////////////////////////////////////////
+
+// MarkerVote represents the Snowball voting state of a single Marker: its current preference, how many consecutive
+// rounds backed that preference (its confidence), and whether it has accumulated enough confidence to be finalized.
+type MarkerVote struct {
+	sequenceID marker.SequenceID
+	index      marker.Index
+
+	preference Opinion
+	confidence uint64
+	finalized  bool
+	stateMutex sync.RWMutex
+
+	objectstorage.StorableObjectFlags
+}
+
+// NewMarkerVote creates a new MarkerVote for the Marker identified by sequenceID and index, with the given starting
+// preference.
+func NewMarkerVote(sequenceID marker.SequenceID, index marker.Index, initialPreference Opinion) *MarkerVote {
+	return &MarkerVote{
+		sequenceID: sequenceID,
+		index:      index,
+		preference: initialPreference,
+	}
+}
+
+// MarkerVoteFromBytes unmarshals a MarkerVote from a sequence of bytes.
+func MarkerVoteFromBytes(markerVoteBytes []byte) (markerVote *MarkerVote, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(markerVoteBytes)
+	if markerVote, err = MarkerVoteFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse MarkerVote from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// MarkerVoteFromMarshalUtil is a wrapper for simplified unmarshaling in a byte stream using the marshalUtil package.
+func MarkerVoteFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (markerVote *MarkerVote, err error) {
+	markerVote = &MarkerVote{}
+	if markerVote.sequenceID, err = marker.SequenceIDFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse SequenceID from MarshalUtil: %w", err)
+		return
+	}
+	if markerVote.index, err = marker.IndexFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Index from MarshalUtil: %w", err)
+		return
+	}
+	if markerVote.preference, err = OpinionFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse preference Opinion from MarshalUtil: %w", err)
+		return
+	}
+	if markerVote.confidence, err = marshalUtil.ReadUint64(); err != nil {
+		err = xerrors.Errorf("failed to parse confidence (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	if markerVote.finalized, err = marshalUtil.ReadBool(); err != nil {
+		err = xerrors.Errorf("failed to parse finalized flag (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+
+	return
+}
+
+// MarkerVoteFromObjectStorage restores a MarkerVote that was stored in the ObjectStorage.
+func MarkerVoteFromObjectStorage(key, data []byte) (markerVote objectstorage.StorableObject, err error) {
+	if markerVote, _, err = MarkerVoteFromBytes(byteutils.ConcatBytes(key, data)); err != nil {
+		err = xerrors.Errorf("failed to parse MarkerVote from bytes: %w", err)
+		return
+	}
+
+	return
+}
+
+// SequenceID returns the SequenceID of the Marker this MarkerVote belongs to.
+func (m *MarkerVote) SequenceID() marker.SequenceID {
+	return m.sequenceID
+}
+
+// Index returns the Index of the Marker this MarkerVote belongs to.
+func (m *MarkerVote) Index() marker.Index {
+	return m.index
+}
+
+// Preference returns the current Snowball preference of the Marker.
+func (m *MarkerVote) Preference() Opinion {
+	m.stateMutex.RLock()
+	defer m.stateMutex.RUnlock()
+
+	return m.preference
+}
+
+// Confidence returns the number of consecutive rounds that backed the current preference.
+func (m *MarkerVote) Confidence() uint64 {
+	m.stateMutex.RLock()
+	defer m.stateMutex.RUnlock()
+
+	return m.confidence
+}
+
+// Finalized returns true if the Marker has accumulated enough confidence to be finalized.
+func (m *MarkerVote) Finalized() bool {
+	m.stateMutex.RLock()
+	defer m.stateMutex.RUnlock()
+
+	return m.finalized
+}
+
+// Tally applies the outcome of a single voting round: if majorityPreference agrees with the current preference, the
+// confidence counter is incremented; otherwise the preference flips to majorityPreference and confidence resets to
+// 1. It returns true if confidence has reached beta with a Like preference, including when the Marker was already
+// finalized before. A Marker that settles on Dislike never finalizes, no matter how high its confidence climbs - it
+// simply means the committee consistently does not (yet) want to confirm it.
+func (m *MarkerVote) Tally(majorityPreference Opinion, beta uint64) (finalized bool) {
+	m.stateMutex.Lock()
+	defer m.stateMutex.Unlock()
+
+	if m.finalized {
+		return true
+	}
+
+	if majorityPreference == m.preference {
+		m.confidence++
+	} else {
+		m.preference = majorityPreference
+		m.confidence = 1
+	}
+
+	if m.confidence >= beta && m.preference == Like {
+		m.finalized = true
+	}
+
+	m.SetModified()
+
+	return m.finalized
+}
+
+// ForceFinalize marks the MarkerVote as finalized with a Like preference and confidence beta, bypassing the normal
+// Tally progression. Manager.finalize uses it to keep a parent Marker's MarkerVote consistent with its Sequence's
+// FinalizedIndex when the parent is finalized purely through cascade, without ever reaching beta through its own
+// voting rounds. It is a no-op if the MarkerVote was already finalized.
+func (m *MarkerVote) ForceFinalize(beta uint64) {
+	m.stateMutex.Lock()
+	defer m.stateMutex.Unlock()
+
+	if m.finalized {
+		return
+	}
+
+	m.preference = Like
+	m.confidence = beta
+	m.finalized = true
+	m.SetModified()
+}
+
+// Bytes returns the MarkerVote in serialized byte form.
+func (m *MarkerVote) Bytes() []byte {
+	return byteutils.ConcatBytes(m.ObjectStorageKey(), m.ObjectStorageValue())
+}
+
+// Update updates the MarkerVote to object storage.
+func (m *MarkerVote) Update(other objectstorage.StorableObject) {
+	panic("updates disabled")
+}
+
+// ObjectStorageKey returns the key that is used to store the object in the database. It is required to match the
+// StorableObject interface.
+func (m *MarkerVote) ObjectStorageKey() []byte {
+	return byteutils.ConcatBytes(m.sequenceID.Bytes(), m.index.Bytes())
+}
+
+// ObjectStorageValue marshals the MarkerVote into a sequence of bytes. The SequenceID and Index are not serialized
+// here as they are only used as a key in the ObjectStorage.
+func (m *MarkerVote) ObjectStorageValue() []byte {
+	m.stateMutex.RLock()
+	defer m.stateMutex.RUnlock()
+
+	return marshalutil.New().
+		Write(m.preference).
+		WriteUint64(m.confidence).
+		WriteBool(m.finalized).
+		Bytes()
+}
+
+var _ objectstorage.StorableObject = &MarkerVote{}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// region CachedMarkerVote /////////////////////////////////////////////////////////////////////////////////////////
+
+// CachedMarkerVote is a wrapper for the generic CachedObject returned by the objectstorage that overrides the
+// accessor methods with a type-casted one.
+type CachedMarkerVote struct {
+	objectstorage.CachedObject
+}
+
+// Retain marks this CachedObject to still be in use by the program.
+func (c *CachedMarkerVote) Retain() *CachedMarkerVote {
+	return &CachedMarkerVote{c.CachedObject.Retain()}
+}
+
+// Unwrap is the type-casted equivalent of Get. It returns nil if the object does not exist.
+func (c *CachedMarkerVote) Unwrap() *MarkerVote {
+	untypedObject := c.Get()
+	if untypedObject == nil {
+		return nil
+	}
+
+	typedObject := untypedObject.(*MarkerVote)
+	if typedObject == nil || typedObject.IsDeleted() {
+		return nil
+	}
+
+	return typedObject
+}
+
+// Consume unwraps the CachedObject and passes a type-casted version to the consumer. It automatically releases the
+// object when the consumer finishes and returns true of there was at least one object that was consumed.
+func (c *CachedMarkerVote) Consume(consumer func(markerVote *MarkerVote), forceRelease ...bool) (consumed bool) {
+	return c.CachedObject.Consume(func(object objectstorage.StorableObject) {
+		consumer(object.(*MarkerVote))
+	}, forceRelease...)
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -0,0 +1,60 @@
+package vote
+
+import (
+	"github.com/iotaledger/hive.go/cerrors"
+	"github.com/iotaledger/hive.go/marshalutil"
+	"golang.org/x/xerrors"
+)
+
+// region Opinion //////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Opinion represents a binary Snowball preference: whether a Voter currently likes (wants to finalize) a Marker.
+type Opinion bool
+
+const (
+	// Dislike is the Opinion expressing that a Voter does not (yet) want to finalize a Marker.
+	Dislike Opinion = false
+
+	// Like is the Opinion expressing that a Voter wants to finalize a Marker.
+	Like Opinion = true
+)
+
+// OpinionFromBytes unmarshals an Opinion from a sequence of bytes.
+func OpinionFromBytes(opinionBytes []byte) (opinion Opinion, consumedBytes int, err error) {
+	marshalUtil := marshalutil.New(opinionBytes)
+	if opinion, err = OpinionFromMarshalUtil(marshalUtil); err != nil {
+		err = xerrors.Errorf("failed to parse Opinion from MarshalUtil: %w", err)
+		return
+	}
+	consumedBytes = marshalUtil.ReadOffset()
+
+	return
+}
+
+// OpinionFromMarshalUtil is a wrapper for simplified unmarshaling in a byte stream using the marshalUtil package.
+func OpinionFromMarshalUtil(marshalUtil *marshalutil.MarshalUtil) (opinion Opinion, err error) {
+	untypedOpinion, err := marshalUtil.ReadBool()
+	if err != nil {
+		err = xerrors.Errorf("failed to parse Opinion (%v): %w", err, cerrors.ErrParseBytesFailed)
+		return
+	}
+	opinion = Opinion(untypedOpinion)
+
+	return
+}
+
+// Bytes returns the Opinion in serialized byte form.
+func (o Opinion) Bytes() []byte {
+	return marshalutil.New().WriteBool(bool(o)).Bytes()
+}
+
+// String returns a human readable version of the Opinion.
+func (o Opinion) String() string {
+	if o {
+		return "Like"
+	}
+
+	return "Dislike"
+}
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////
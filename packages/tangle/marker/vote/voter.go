@@ -0,0 +1,32 @@
+package vote
+
+import (
+	"github.com/iotaledger/goshimmer/packages/tangle/marker"
+)
+
+// region injection points /////////////////////////////////////////////////////////////////////////////////////////
+
+// Voter identifies a peer that can be polled for its opinion on a Marker. It is intentionally minimal so that
+// whatever peer/gossip layer the node already runs can be plugged into the Manager without this package depending
+// on it.
+type Voter interface {
+	// ID returns a value that uniquely identifies the Voter within a single voting round.
+	ID() string
+}
+
+// PeerPicker selects the committee of Voters that is polled during a single voting round.
+type PeerPicker func(count int) []Voter
+
+// Query asks a single Voter for its current preference on the Marker identified by sequenceID and index. An error
+// is treated as an abstention and does not count towards either opinion.
+type Query func(voter Voter, sequenceID marker.SequenceID, index marker.Index) (opinion Opinion, err error)
+
+// SequenceRetriever returns the CachedSequence for the given SequenceID, or nil if it is not known.
+type SequenceRetriever func(sequenceID marker.SequenceID) *marker.CachedSequence
+
+// ParentMarkersRetriever returns the highest referenced parent Marker of every parent Sequence for the Marker
+// identified by sequenceID and index, i.e. Sequence.HighestReferencedParentMarkers translated into a plain map by
+// whatever component owns the marker DAG.
+type ParentMarkersRetriever func(sequenceID marker.SequenceID, index marker.Index) map[marker.SequenceID]marker.Index
+
+// endregion ///////////////////////////////////////////////////////////////////////////////////////////////////////